@@ -4,118 +4,96 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
-	"strings"
 
-	"github.com/irisnet/irishub/codec"
 	sdk "github.com/irisnet/irishub/types"
 	"github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 const (
-	ed25519VerifyCost   = 59
-	secp256k1VerifyCost = 100
-
 	// if gas > gasShift, gas = log(gas)/log(gasBase)
 	// else gasConsumed = gas
 	gasBase  = 1.02 // gas logarithm base
 	gasShift = 285  // gas logarithm shift
 )
 
-// NewAnteHandler returns an AnteHandler that checks
-// and increments sequence numbers, checks signatures & account numbers,
-// and deducts fees from the first signer.
-func NewAnteHandler(am AccountKeeper, fck FeeKeeper) sdk.AnteHandler {
-	return func(
-		ctx sdk.Context, tx sdk.Tx, simulate bool,
-	) (newCtx sdk.Context, res sdk.Result, abort bool) {
-
-		// This AnteHandler requires Txs to be StdTxs
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			// Set a gas meter with limit 0 as to prevent an infinite gas meter attack
-			// during runTx.
-			newCtx = setGasMeter(simulate, ctx, 0)
-			return newCtx, sdk.ErrInternal("tx must be StdTx").Result(), true
-		}
-
-		// Ensure that the provided fees meet a minimum threshold for the validator, if this is a CheckTx.
-		// This is only for local mempool purposes, and thus is only ran on check tx.
-		if ctx.IsCheckTx() && !simulate {
-			res := ensureSufficientMempoolFees(ctx, stdTx)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
-		}
+// AnteDecorator wraps the rest of the ante chain (next) to perform a single,
+// narrowly-scoped piece of pre-processing on a Tx. A decorator calls next to
+// continue the chain, or returns early with abort=true to short-circuit it.
+// Apps assemble their own chain with ChainAnteDecorators instead of forking
+// NewAnteHandler when they need to reorder, skip, or add checks.
+type AnteDecorator interface {
+	AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, res sdk.Result, abort bool)
+}
 
-		newCtx = setGasMeter(simulate, ctx, stdTx.Fee.Gas)
+// terminatorDecorator is appended to the end of every chain so the last
+// decorator always has a well-defined next to call into.
+type terminatorDecorator struct{}
 
-		// AnteHandlers must have their own defer/recover in order
-		// for the BaseApp to know how much gas was used!
-		// This is because the GasMeter is created in the AnteHandler,
-		// but if it panics the context won't be set properly in runTx's recover ...
-		defer func() {
-			if r := recover(); r != nil {
-				switch rType := r.(type) {
-				case sdk.ErrorOutOfGas:
-					log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
-					res = sdk.ErrOutOfGas(log).Result()
-					res.GasWanted = stdTx.Fee.Gas
-					res.GasUsed = newCtx.GasMeter().GasConsumed()
-					abort = true
-				default:
-					panic(r)
-				}
-			}
-		}()
+func (terminatorDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, _ sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+	return ctx, sdk.Result{}, false
+}
 
-		if err := tx.ValidateBasic(); err != nil {
-			return newCtx, err.Result(), true
-		}
+var terminator AnteDecorator = terminatorDecorator{}
 
-		// stdSigs contains the sequence number, account number, and signatures.
-		// When simulating, this would just be a 0-length slice.
-		stdSigs := stdTx.GetSignatures()
-		signerAddrs := stdTx.GetSigners()
+// ChainAnteDecorators chains the given AnteDecorators into a single
+// AnteHandler, wiring each decorator's next to the handler built from the
+// remainder of the chain.
+func ChainAnteDecorators(chain ...AnteDecorator) sdk.AnteHandler {
+	if len(chain) == 0 {
+		return nil
+	}
 
-		// create the list of all sign bytes
-		signBytesList := getSignBytesList(newCtx.ChainID(), stdTx, stdSigs)
-		signerAccs, res := getSignerAccs(newCtx, am, signerAddrs)
-		if !res.IsOK() {
-			return newCtx, res, true
-		}
-		res = validateAccNumAndSequence(ctx, signerAccs, stdSigs)
-		if !res.IsOK() {
-			return newCtx, res, true
-		}
+	if chain[len(chain)-1] != terminator {
+		chain = append(chain, terminator)
+	}
 
-		// first sig pays the fees
-		if !stdTx.Fee.Amount.IsZero() {
-			// signerAccs[0] is the fee payer
-			signerAccs[0], res = deductFees(signerAccs[0], stdTx.Fee)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
-			fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
-		}
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		return chain[0].AnteHandle(ctx, tx, simulate, ChainAnteDecorators(chain[1:]...))
+	}
+}
 
-		for i := 0; i < len(stdSigs); i++ {
-			// check signature, return account with incremented nonce
-			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], simulate)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+// NewAnteHandler returns the default AnteHandler, assembled from the
+// standard decorator chain, that checks account numbers and sequences up
+// front, increments sequence numbers, checks signatures, and deducts fees
+// from the first signer (or from the tx's Granter, via fgk, when fee-grant
+// delegation is used). sigGasConsumer determines the gas cost of verifying
+// each signer's PubKey; pass DefaultSigVerificationGasConsumer to get
+// today's behavior, or a custom consumer to price additional key schemes.
+// fgk and bfk may be nil if the app does not support fee grants or the
+// dynamic on-chain BaseFee, respectively. Apps that want to reorder, skip,
+// or add decorators (e.g. rate limits, allowlists) should call
+// ChainAnteDecorators with their own list.
+func NewAnteHandler(
+	am AccountKeeper, fck FeeKeeper, sigGasConsumer SignatureVerificationGasConsumer,
+	fgk FeeGrantKeeper, bfk BaseFeeKeeper,
+) sdk.AnteHandler {
+	return ChainAnteDecorators(
+		NewSetUpContextDecorator(),
+		NewValidateBasicDecorator(),
+		NewValidateMemoDecorator(am),
+		NewMempoolFeeDecorator(bfk),
+		NewValidateAccNumSeqDecorator(am),
+		NewDeductFeeDecorator(am, fck, fgk),
+		NewSetPubKeyDecorator(am),
+		NewSigGasConsumeDecorator(am, sigGasConsumer),
+		NewSigVerificationDecorator(am),
+		NewIncrementSequenceDecorator(am),
+	)
+}
 
-			// Save the account.
-			am.SetAccount(newCtx, signerAccs[i])
+// RouteAnteHandler combines stdHandler (built with NewAnteHandler) and
+// ethHandler (built with NewEthAnteHandler) into the single AnteHandler an
+// app registers with BaseApp, dispatching each tx to the one that matches
+// its concrete decoded type.
+func RouteAnteHandler(stdHandler, ethHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		switch tx.(type) {
+		case EthTx:
+			return ethHandler(ctx, tx, simulate)
+		default:
+			return stdHandler(ctx, tx, simulate)
 		}
-
-		// cache the signer accounts in the context
-		newCtx = WithSigners(newCtx, signerAccs)
-
-		// TODO: tx tags (?)
-		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
 	}
 }
 
@@ -155,37 +133,6 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []Account, sigs []StdSignat
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
-// if the account doesn't have a pubkey, set it.
-func processSig(ctx sdk.Context,
-	acc Account, sig StdSignature, signBytes []byte, simulate bool) (updatedAcc Account, res sdk.Result) {
-	pubKey, res := processPubKey(acc, sig, simulate)
-	if !res.IsOK() {
-		return nil, res
-	}
-	err := acc.SetPubKey(pubKey)
-	if err != nil {
-		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
-	}
-
-	if res := consumeSignatureVerificationGas(ctx.GasMeter(), sig.Signature, pubKey); !res.IsOK() {
-		return nil, res
-	}
-
-	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
-	}
-
-	// increment the sequence number
-	err = acc.SetSequence(acc.GetSequence() + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
-	}
-
-	return acc, res
-}
-
 var dummySecp256k1Pubkey secp256k1.PubKeySecp256k1
 
 func init() {
@@ -193,6 +140,9 @@ func init() {
 	copy(dummySecp256k1Pubkey[:], bz)
 }
 
+// processPubKey determines the pubkey to verify against: the account's own,
+// or, when the account has none yet, the one in the StdSignature. It does not
+// set the pubkey on the account; the caller does.
 func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey, sdk.Result) {
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
@@ -220,51 +170,12 @@ func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey,
 	return pubKey, sdk.Result{}
 }
 
-func consumeSignatureVerificationGas(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey) sdk.Result {
-
-	pubkeyType := strings.ToLower(fmt.Sprintf("%T", pubkey))
-
-	switch {
-	case strings.Contains(pubkeyType, "ed25519"):
-		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
-		return sdk.Result{}
-
-	case strings.Contains(pubkeyType, "secp256k1"):
-		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
-		return sdk.Result{}
-
-	case strings.Contains(pubkeyType, "multisigthreshold"):
-		var multisignature multisig.Multisignature
-		codec.Cdc.MustUnmarshalBinaryBare(sig, &multisignature)
-
-		multisigPubKey := pubkey.(multisig.PubKeyMultisigThreshold)
-		consumeMultisignatureVerificationGas(meter, multisignature, multisigPubKey)
-		return sdk.Result{}
-
-	default:
-		return sdk.ErrInvalidPubKey(fmt.Sprintf("unrecognized public key type: %s", pubkeyType)).Result()
-	}
-}
-
-func consumeMultisignatureVerificationGas(meter sdk.GasMeter,
-	sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold) {
-
-	size := sig.BitArray.Size()
-	sigIndex := 0
-	for i := 0; i < size; i++ {
-		if sig.BitArray.GetIndex(i) {
-			consumeSignatureVerificationGas(meter, sig.Sigs[sigIndex], pubkey.PubKeys[i])
-			sigIndex++
-		}
-	}
-}
-
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountKeeper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+func deductFees(acc Account, fee Fee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
-	feeAmount := fee.Amount
+	feeAmount := fee.GetAmount()
 
 	newCoins, hasNeg := coins.SafeSub(feeAmount)
 	if hasNeg {
@@ -281,26 +192,6 @@ func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
 	return acc, sdk.Result{}
 }
 
-func ensureSufficientMempoolFees(ctx sdk.Context, stdTx StdTx) sdk.Result {
-	// currently we use a very primitive gas pricing model with a constant gasPrice.
-	// adjustFeesByGas handles calculating the amount of fees required based on the provided gas.
-	//
-	// TODO:
-	// - Make the gasPrice not a constant, and account for tx size.
-	// - Make Gas an unsigned integer and use tx basic validation
-	if stdTx.Fee.Gas <= 0 {
-		return sdk.ErrInternal(fmt.Sprintf("invalid gas supplied: %d", stdTx.Fee.Gas)).Result()
-	}
-
-	// NOTE: !A.IsAllGTE(B) is not the same as A.IsAllLT(B).
-	if !ctx.MinimumFees().IsZero() && !stdTx.Fee.Amount.IsAllGTE(ctx.MinimumFees()) {
-		// validators reject any tx from the mempool with less than the minimum fee per gas * gas factor
-		return sdk.ErrInsufficientFee(fmt.Sprintf(
-			"insufficient fee, got: %q required: %q", stdTx.Fee.Amount, ctx.MinimumFees())).Result()
-	}
-	return sdk.Result{}
-}
-
 func setGasMeter(simulate bool, ctx sdk.Context, gasLimit uint64) sdk.Context {
 	// In various cases such as simulation and during the genesis block, we do not
 	// meter any gas utilization.
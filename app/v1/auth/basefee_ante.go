@@ -0,0 +1,14 @@
+package auth
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// BaseFeeKeeper exposes the on-chain, per-block-adjusted BaseFee consulted
+// by MempoolFeeDecorator in place of a static minimum fee. It is
+// implemented by the x/fee keeper; apps that don't wire up dynamic base-fee
+// adjustment may pass nil, in which case the validator's locally configured
+// minimum fees are used unchanged.
+type BaseFeeKeeper interface {
+	GetBaseFee(ctx sdk.Context) sdk.Coins
+}
@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"github.com/irisnet/irishub/codec"
+)
+
+// RegisterCodec registers auth's concrete types - StdTx's Fee
+// implementations and EthTx - on cdc, so both can be amino (de)serialized
+// wherever an sdk.Tx or sdk.Msg is.
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*Fee)(nil), nil)
+	cdc.RegisterConcrete(StdFee{}, "irishub/auth/StdFee", nil)
+	cdc.RegisterConcrete(DynamicFee{}, "irishub/auth/DynamicFee", nil)
+
+	registerEthTxCodec(cdc)
+}
@@ -0,0 +1,420 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// SetUpContextDecorator sets up the gas meter for the rest of the chain and
+// installs the panic/out-of-gas recovery that the BaseApp relies on to learn
+// how much gas was used. It must run first so every downstream decorator is
+// metered and covered by the recover.
+type SetUpContextDecorator struct{}
+
+// NewSetUpContextDecorator returns a new SetUpContextDecorator.
+func NewSetUpContextDecorator() SetUpContextDecorator {
+	return SetUpContextDecorator{}
+}
+
+func (sud SetUpContextDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		// Set a gas meter with limit 0 as to prevent an infinite gas meter attack
+		// during runTx.
+		newCtx = setGasMeter(simulate, ctx, 0)
+		return newCtx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	newCtx = setGasMeter(simulate, ctx, stdTx.Fee.GetGas())
+
+	// AnteHandlers must have their own defer/recover in order
+	// for the BaseApp to know how much gas was used!
+	// This is because the GasMeter is created in the AnteHandler,
+	// but if it panics the context won't be set properly in runTx's recover ...
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
+				res = sdk.ErrOutOfGas(log).Result()
+				res.GasWanted = stdTx.Fee.GetGas()
+				res.GasUsed = newCtx.GasMeter().GasConsumed()
+				abort = true
+			default:
+				panic(r)
+			}
+		}
+	}()
+
+	return next(newCtx, tx, simulate)
+}
+
+// ValidateBasicDecorator runs the Tx's own stateless ValidateBasic check.
+type ValidateBasicDecorator struct{}
+
+// NewValidateBasicDecorator returns a new ValidateBasicDecorator.
+func NewValidateBasicDecorator() ValidateBasicDecorator {
+	return ValidateBasicDecorator{}
+}
+
+func (vbd ValidateBasicDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	if err := tx.ValidateBasic(); err != nil {
+		return ctx, err.Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+// ValidateMemoDecorator rejects StdTxs whose memo exceeds the maximum
+// allowed size (a governance-configurable auth param, read via am),
+// independent of the rest of ValidateBasic.
+type ValidateMemoDecorator struct {
+	am AccountKeeper
+}
+
+// NewValidateMemoDecorator returns a new ValidateMemoDecorator.
+func NewValidateMemoDecorator(am AccountKeeper) ValidateMemoDecorator {
+	return ValidateMemoDecorator{am: am}
+}
+
+func (vmd ValidateMemoDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	maxMemoChars := vmd.am.GetParams(ctx).MaxMemoCharacters
+	memoLength := uint64(len(stdTx.Memo))
+	if memoLength > maxMemoChars {
+		return ctx, sdk.ErrInternal(
+			fmt.Sprintf("maximum number of characters is %d but received %d characters",
+				maxMemoChars, memoLength)).Result(), true
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// MempoolFeeDecorator rejects txs, on CheckTx only, whose fee falls below
+// the validator's locally configured minimum fees, or below the on-chain
+// BaseFee when bfk is set.
+type MempoolFeeDecorator struct {
+	bfk BaseFeeKeeper
+}
+
+// NewMempoolFeeDecorator returns a new MempoolFeeDecorator. bfk may be nil,
+// in which case only the validator's local minimum fees are enforced.
+func NewMempoolFeeDecorator(bfk BaseFeeKeeper) MempoolFeeDecorator {
+	return MempoolFeeDecorator{bfk: bfk}
+}
+
+func (mfd MempoolFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	// Ensure that the provided fees meet a minimum threshold for the validator, if this is a CheckTx.
+	// This is only for local mempool purposes, and thus is only ran on check tx.
+	if ctx.IsCheckTx() && !simulate {
+		if res := ensureSufficientMempoolFees(ctx, stdTx.Fee, mfd.bfk); !res.IsOK() {
+			return ctx, res, true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// ValidateAccNumSeqDecorator checks every signer's account number and
+// sequence before any fee is deducted or any signature is verified, so a tx
+// with a bad account number or sequence anywhere is rejected atomically,
+// before the chain pays for (or does) any of that downstream work. This
+// keeps bad-sequence spam - e.g. a multi-signer tx with a stale sequence on
+// one of its later signers - cheap to reject.
+type ValidateAccNumSeqDecorator struct {
+	am AccountKeeper
+}
+
+// NewValidateAccNumSeqDecorator returns a new ValidateAccNumSeqDecorator.
+func NewValidateAccNumSeqDecorator(am AccountKeeper) ValidateAccNumSeqDecorator {
+	return ValidateAccNumSeqDecorator{am: am}
+}
+
+func (vasd ValidateAccNumSeqDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	if simulate {
+		return next(ctx, tx, simulate)
+	}
+
+	signerAccs, res := getSignerAccs(ctx, vasd.am, stdTx.GetSigners())
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+
+	if res := validateAccNumAndSequence(ctx, signerAccs, stdTx.GetSignatures()); !res.IsOK() {
+		return ctx, res, true
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// FeeKeeper collects the fees DeductFeeDecorator and NewEthAnteHandler
+// deduct from a tx's payer, and gives RefundEthGas a way to hand back the
+// portion of an EthTx's up-front gas charge that execution didn't use.
+type FeeKeeper interface {
+	AddCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins
+	DeductCollectedFees(ctx sdk.Context, coins sdk.Coins) sdk.Coins
+}
+
+// DeductFeeDecorator deducts the tx's Fee from the fee payer's account and
+// credits it to the FeeKeeper's collected fees pool. The fee payer is the
+// first signer, unless the tx names a Granter, in which case the fee is
+// deducted from the granter's account through the FeeGrantKeeper instead -
+// the signer still pays gas for its own signature verification, but no
+// coins.
+type DeductFeeDecorator struct {
+	am  AccountKeeper
+	fck FeeKeeper
+	fgk FeeGrantKeeper
+}
+
+// NewDeductFeeDecorator returns a new DeductFeeDecorator. fgk may be nil if
+// the app does not support fee grants; a tx with a Granter set is then
+// rejected.
+func NewDeductFeeDecorator(am AccountKeeper, fck FeeKeeper, fgk FeeGrantKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{am: am, fck: fck, fgk: fgk}
+}
+
+func (dfd DeductFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	if !stdTx.Fee.GetAmount().IsZero() {
+		// Fee deduction is bookkeeping, not user-requested work, so it runs
+		// under its own infinite gas meter rather than eating into the tx's
+		// declared gas limit. The real gas meter is restored below before
+		// control passes to the rest of the chain.
+		feeCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+		signerAddr := stdTx.GetSigners()[0]
+		payerAddr := signerAddr
+		if payer := stdTx.Fee.GetPayer(); !payer.Empty() {
+			payerAddr = payer
+		}
+
+		if !payerAddr.Equals(signerAddr) {
+			if dfd.fgk == nil {
+				return ctx, sdk.ErrUnauthorized("fee grants are not supported on this chain").Result(), true
+			}
+			if err := dfd.fgk.UseGrantedFees(feeCtx, payerAddr, signerAddr, stdTx.Fee.GetAmount()); err != nil {
+				return ctx, err.Result(), true
+			}
+			dfd.fck.AddCollectedFees(feeCtx, stdTx.Fee.GetAmount())
+			return next(ctx, tx, simulate)
+		}
+
+		payerAcc := dfd.am.GetAccount(feeCtx, payerAddr)
+		if payerAcc == nil {
+			return ctx, sdk.ErrUnknownAddress(payerAddr.String()).Result(), true
+		}
+
+		payerAcc, res := deductFees(payerAcc, stdTx.Fee)
+		if !res.IsOK() {
+			return ctx, res, true
+		}
+		dfd.am.SetAccount(feeCtx, payerAcc)
+		dfd.fck.AddCollectedFees(feeCtx, stdTx.Fee.GetAmount())
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// SetPubKeyDecorator sets the pubkey on a signer's account the first time it
+// is seen, reading it from the StdSignature when the account has none yet.
+type SetPubKeyDecorator struct {
+	am AccountKeeper
+}
+
+// NewSetPubKeyDecorator returns a new SetPubKeyDecorator.
+func NewSetPubKeyDecorator(am AccountKeeper) SetPubKeyDecorator {
+	return SetPubKeyDecorator{am: am}
+}
+
+func (spkd SetPubKeyDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	stdSigs := stdTx.GetSignatures()
+	signerAddrs := stdTx.GetSigners()
+
+	for i, addr := range signerAddrs {
+		acc := spkd.am.GetAccount(ctx, addr)
+		if acc == nil {
+			return ctx, sdk.ErrUnknownAddress(addr.String()).Result(), true
+		}
+
+		pubKey, res := processPubKey(acc, stdSigs[i], simulate)
+		if !res.IsOK() {
+			return ctx, res, true
+		}
+
+		if err := acc.SetPubKey(pubKey); err != nil {
+			return ctx, sdk.ErrInternal("setting PubKey on signer's account").Result(), true
+		}
+
+		spkd.am.SetAccount(ctx, acc)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// SigGasConsumeDecorator consumes gas for signature verification, via the
+// supplied SignatureVerificationGasConsumer, before the (potentially
+// expensive) verification itself is performed.
+type SigGasConsumeDecorator struct {
+	am             AccountKeeper
+	sigGasConsumer SignatureVerificationGasConsumer
+}
+
+// NewSigGasConsumeDecorator returns a new SigGasConsumeDecorator.
+func NewSigGasConsumeDecorator(am AccountKeeper, sigGasConsumer SignatureVerificationGasConsumer) SigGasConsumeDecorator {
+	return SigGasConsumeDecorator{am: am, sigGasConsumer: sigGasConsumer}
+}
+
+func (sgcd SigGasConsumeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	stdSigs := stdTx.GetSignatures()
+	signerAddrs := stdTx.GetSigners()
+
+	for i, addr := range signerAddrs {
+		signerAcc := sgcd.am.GetAccount(ctx, addr)
+		if signerAcc == nil {
+			return ctx, sdk.ErrUnknownAddress(addr.String()).Result(), true
+		}
+
+		pubKey := signerAcc.GetPubKey()
+		if simulate && pubKey == nil {
+			pubKey = dummySecp256k1Pubkey
+		}
+
+		if res := sgcd.sigGasConsumer(ctx.GasMeter(), stdSigs[i].Signature, pubKey); !res.IsOK() {
+			return ctx, res, true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// SigVerificationDecorator verifies each signer's signature against the
+// tx's sign bytes. Account number and sequence have already been checked by
+// ValidateAccNumSeqDecorator earlier in the chain.
+type SigVerificationDecorator struct {
+	am AccountKeeper
+}
+
+// NewSigVerificationDecorator returns a new SigVerificationDecorator.
+func NewSigVerificationDecorator(am AccountKeeper) SigVerificationDecorator {
+	return SigVerificationDecorator{am: am}
+}
+
+func (svd SigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	stdSigs := stdTx.GetSignatures()
+	signerAddrs := stdTx.GetSigners()
+
+	signerAccs, res := getSignerAccs(ctx, svd.am, signerAddrs)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+
+	if simulate {
+		return next(ctx, tx, simulate)
+	}
+
+	signBytesList := getSignBytesList(ctx.ChainID(), stdTx, stdSigs)
+	for i, acc := range signerAccs {
+		if !acc.GetPubKey().VerifyBytes(signBytesList[i], stdSigs[i].Signature) {
+			return ctx, sdk.ErrUnauthorized("signature verification failed").Result(), true
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// IncrementSequenceDecorator increments every signer's sequence number and
+// caches the resulting accounts in the context for downstream modules. It
+// runs last so that a failure anywhere earlier in the chain leaves sequence
+// numbers untouched.
+type IncrementSequenceDecorator struct {
+	am AccountKeeper
+}
+
+// NewIncrementSequenceDecorator returns a new IncrementSequenceDecorator.
+func NewIncrementSequenceDecorator(am AccountKeeper) IncrementSequenceDecorator {
+	return IncrementSequenceDecorator{am: am}
+}
+
+func (isd IncrementSequenceDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	signerAddrs := stdTx.GetSigners()
+	signerAccs := make([]Account, len(signerAddrs))
+
+	for i, addr := range signerAddrs {
+		acc := isd.am.GetAccount(ctx, addr)
+		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+			// Handle w/ #870
+			panic(err)
+		}
+		isd.am.SetAccount(ctx, acc)
+		signerAccs[i] = acc
+	}
+
+	// cache the signer accounts in the context
+	newCtx := WithSigners(ctx, signerAccs)
+
+	// TODO: tx tags (?)
+	newCtx, res, abort := next(newCtx, tx, simulate)
+	if !abort {
+		res.GasWanted = stdTx.Fee.GetGas()
+	}
+	return newCtx, res, abort
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"testing"
+
+	sdk "github.com/irisnet/irishub/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// testAccount is a minimal Account for exercising the ante decorators
+// without a real AccountKeeper/store.
+type testAccount struct {
+	address sdk.AccAddress
+	number  uint64
+	seq     uint64
+	pubKey  crypto.PubKey
+	coins   sdk.Coins
+}
+
+func (a *testAccount) GetAddress() sdk.AccAddress       { return a.address }
+func (a *testAccount) GetAccountNumber() uint64         { return a.number }
+func (a *testAccount) GetSequence() uint64              { return a.seq }
+func (a *testAccount) SetSequence(seq uint64) error     { a.seq = seq; return nil }
+func (a *testAccount) GetPubKey() crypto.PubKey         { return a.pubKey }
+func (a *testAccount) SetPubKey(pk crypto.PubKey) error { a.pubKey = pk; return nil }
+func (a *testAccount) GetCoins() sdk.Coins              { return a.coins }
+func (a *testAccount) SetCoins(coins sdk.Coins) error   { a.coins = coins; return nil }
+
+// testAccountKeeper is a minimal in-memory AccountKeeper backing testAccount.
+type testAccountKeeper struct {
+	accs map[string]*testAccount
+}
+
+func newTestAccountKeeper(accs ...*testAccount) *testAccountKeeper {
+	k := &testAccountKeeper{accs: map[string]*testAccount{}}
+	for _, a := range accs {
+		k.accs[a.address.String()] = a
+	}
+	return k
+}
+
+func (k *testAccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) Account {
+	a, ok := k.accs[addr.String()]
+	if !ok {
+		return nil
+	}
+	return a
+}
+
+func (k *testAccountKeeper) SetAccount(ctx sdk.Context, acc Account) {
+	k.accs[acc.GetAddress().String()] = acc.(*testAccount)
+}
+
+// fakeMsg is a minimal sdk.Msg with a single fixed signer, standing in for
+// a real Msg so a StdTx's GetSigners can be exercised without depending on
+// any concrete message type.
+type fakeMsg struct {
+	signer sdk.AccAddress
+}
+
+func (m fakeMsg) Route() string                { return "test" }
+func (m fakeMsg) Type() string                 { return "test" }
+func (m fakeMsg) ValidateBasic() sdk.Error     { return nil }
+func (m fakeMsg) GetSignBytes() []byte         { return []byte("test") }
+func (m fakeMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.signer} }
+
+// TestValidateAccNumSeqDecoratorSkipsSigVerification checks that a 5-msg tx
+// with a bad sequence on signer #3 is rejected by ValidateAccNumSeqDecorator
+// before SigGasConsumeDecorator or SigVerificationDecorator ever run - so a
+// spammed tx with a stale sequence on a later signer costs nothing beyond
+// the fixed cost of checking account numbers and sequences, not a secp256k1
+// verification per signer.
+func TestValidateAccNumSeqDecoratorSkipsSigVerification(t *testing.T) {
+	const numSigners = 5
+	const badSigner = 2 // signer #3, zero-indexed
+
+	accs := make([]*testAccount, numSigners)
+	signers := make([]sdk.AccAddress, numSigners)
+	msgs := make([]sdk.Msg, numSigners)
+	sigs := make([]StdSignature, numSigners)
+	for i := 0; i < numSigners; i++ {
+		priv := secp256k1.GenPrivKey()
+		pub := priv.PubKey()
+		addr := sdk.AccAddress(pub.Address())
+
+		accs[i] = &testAccount{address: addr, number: uint64(i), seq: uint64(i), pubKey: pub}
+		signers[i] = addr
+		msgs[i] = fakeMsg{signer: addr}
+		sigs[i] = StdSignature{
+			PubKey:        pub,
+			AccountNumber: uint64(i),
+			Sequence:      uint64(i),
+		}
+	}
+	// Signer #3 presents a wrong sequence, one ahead of its account's.
+	sigs[badSigner].Sequence = accs[badSigner].seq + 1
+
+	am := newTestAccountKeeper(accs...)
+
+	verifyCount := 0
+	countingConsumer := func(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey) sdk.Result {
+		verifyCount++
+		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
+		return sdk.Result{}
+	}
+
+	chain := ChainAnteDecorators(
+		NewValidateAccNumSeqDecorator(am),
+		NewSetPubKeyDecorator(am),
+		NewSigGasConsumeDecorator(am, countingConsumer),
+		NewSigVerificationDecorator(am),
+	)
+
+	tx := StdTx{
+		Msgs:       msgs,
+		Fee:        StdFee{Gas: 200000},
+		Signatures: sigs,
+		Memo:       "",
+	}
+
+	// A nonzero BlockHeight keeps validateAccNumAndSequence on its normal
+	// path; height 0 is reserved for InitChain, where every account number
+	// must be 0.
+	ctx := sdk.Context{}.WithBlockHeight(100).WithGasMeter(sdk.NewInfiniteGasMeter())
+	_, res, abort := chain(ctx, tx, false)
+
+	if !abort || res.IsOK() {
+		t.Fatalf("expected tx with a stale sequence on signer #3 to be rejected, got res=%v abort=%v", res, abort)
+	}
+	if verifyCount != 0 {
+		t.Fatalf("expected zero secp256k1 verifications, got %d", verifyCount)
+	}
+	if gasUsed := ctx.GasMeter().GasConsumed(); gasUsed != 0 {
+		t.Fatalf("expected a bad sequence to be rejected before any gas is consumed, got %d", gasUsed)
+	}
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// ethTxFeeDenom is the denom an EthTx's gasPrice*gasLimit is charged in -
+// irishub's native staking token.
+const ethTxFeeDenom = "iris-atto"
+
+// ethChainID derives the numeric EIP-155 chain ID that Ethereum wallets
+// sign over from the chain's cosmos chain-id, which by convention ends in
+// "-<numeric-id>" (e.g. "irishub-1" -> 1). Chains that don't follow this
+// convention get chain ID 0, which disables EIP-155 replay protection for
+// EthTxs.
+func ethChainID(ctx sdk.Context) *big.Int {
+	raw := ctx.ChainID()
+	if i := strings.LastIndex(raw, "-"); i >= 0 {
+		raw = raw[i+1:]
+	}
+	chainID, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return chainID
+}
+
+// NewEthAnteHandler returns the AnteHandler for EthTx transactions. Unlike
+// NewAnteHandler's decorator chain, it authenticates the sender by
+// recovering it from the tx's own secp256k1 signature rather than matching
+// a detached StdSignature against a known signer, so it runs as a single,
+// self-contained handler. Use RouteAnteHandler to dispatch between this and
+// NewAnteHandler based on the decoded tx's concrete type.
+func NewEthAnteHandler(am AccountKeeper, fck FeeKeeper) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		ethTx, ok := tx.(EthTx)
+		if !ok {
+			return ctx, sdk.ErrInternal("tx must be EthTx").Result(), true
+		}
+
+		newCtx = setGasMeter(simulate, ctx, ethTx.GasLimit)
+
+		if err := ethTx.ValidateBasic(); err != nil {
+			return ctx, err.Result(), true
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				switch rType := r.(type) {
+				case sdk.ErrorOutOfGas:
+					res = sdk.ErrOutOfGas(fmt.Sprintf("out of gas in location: %v", rType.Descriptor)).Result()
+					res.GasWanted = ethTx.GasLimit
+					res.GasUsed = newCtx.GasMeter().GasConsumed()
+					abort = true
+				default:
+					panic(r)
+				}
+			}
+		}()
+
+		sender, err := ethTx.Sender(ethChainID(ctx))
+		if err != nil {
+			return ctx, sdk.ErrUnauthorized(fmt.Sprintf("could not recover eth tx sender: %s", err)).Result(), true
+		}
+		ethTx.from = sender
+
+		acc := am.GetAccount(newCtx, sender)
+		if acc == nil {
+			acc = am.NewAccountWithAddress(newCtx, sender)
+		}
+
+		if !simulate && ethTx.Nonce != acc.GetSequence() {
+			return ctx, sdk.ErrInvalidSequence(fmt.Sprintf(
+				"invalid nonce. got %d, expected %d", ethTx.Nonce, acc.GetSequence())).Result(), true
+		}
+
+		newCtx.GasMeter().ConsumeGas(secp256k1VerifyCost+intrinsicGas(ethTx.Data), "ante verify: eth tx")
+
+		// Deduct gasPrice*gasLimit up front, under an infinite sub-meter so
+		// the bookkeeping write isn't charged against the tx's own gas
+		// budget - the same treatment DeductFeeDecorator gives StdTx. The
+		// unused portion, once the EVM message's actual gas use is known,
+		// is returned by RefundEthGas.
+		feeCtx := newCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		cost := sdk.NewCoins(sdk.NewCoin(ethTxFeeDenom,
+			sdk.NewIntFromBigInt(new(big.Int).Mul(ethTx.GasPrice, new(big.Int).SetUint64(ethTx.GasLimit)))))
+
+		coins, hasNeg := acc.GetCoins().SafeSub(cost)
+		if hasNeg {
+			return ctx, sdk.ErrInsufficientFunds(fmt.Sprintf(
+				"account balance [%s] is not enough to cover eth gas cost [%s]", acc.GetCoins(), cost)).Result(), true
+		}
+		if err := acc.SetCoins(coins); err != nil {
+			panic(err)
+		}
+		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+			panic(err)
+		}
+		am.SetAccount(feeCtx, acc)
+		fck.AddCollectedFees(feeCtx, cost)
+
+		return newCtx, sdk.Result{}, false
+	}
+}
+
+// RefundEthGas refunds the portion of an EthTx's up-front gasPrice*GasLimit
+// charge that execution didn't use. NewEthAnteHandler charges for the tx's
+// full declared GasLimit before the EVM message runs, since the actual
+// amount it will consume isn't known until after; gasUsed is that actual
+// amount, reported by whatever runs the EVM message. Intended to be called
+// from the app's post-execution hook for every EthTx, after NewEthAnteHandler
+// has run. The refund goes to the sender recovered from tx's own signature.
+func RefundEthGas(ctx sdk.Context, am AccountKeeper, fck FeeKeeper, tx EthTx, gasUsed uint64) sdk.Result {
+	if gasUsed >= tx.GasLimit {
+		return sdk.Result{}
+	}
+
+	unused := tx.GasLimit - gasUsed
+	refund := sdk.NewCoins(sdk.NewCoin(ethTxFeeDenom,
+		sdk.NewIntFromBigInt(new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(unused)))))
+	if refund.IsZero() {
+		return sdk.Result{}
+	}
+
+	// NewEthAnteHandler sets from on its own copy of the tx only, so the
+	// sender is recovered again here.
+	sender, err := tx.Sender(ethChainID(ctx))
+	if err != nil {
+		return sdk.ErrUnauthorized(fmt.Sprintf("could not recover eth tx sender: %s", err)).Result()
+	}
+
+	acc := am.GetAccount(ctx, sender)
+	if acc == nil {
+		return sdk.ErrUnknownAddress(sender.String()).Result()
+	}
+
+	// Bookkeeping, not user-requested work - see the matching comment in
+	// NewEthAnteHandler.
+	feeCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if err := acc.SetCoins(acc.GetCoins().Add(refund)); err != nil {
+		panic(err)
+	}
+	am.SetAccount(feeCtx, acc)
+	fck.DeductCollectedFees(feeCtx, refund)
+
+	return sdk.Result{}
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// registerEthTxCodec registers EthTx on the app-wide codec, so it can be
+// amino-marshaled the same way a StdTx is. Called from RegisterCodec;
+// kept in its own function, rather than folded directly into it, so
+// eth_tx.go's codec registration lives next to the rest of its eth tx
+// support.
+func registerEthTxCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(EthTx{}, "irishub/auth/EthTx", nil)
+}
+
+// DefaultTxDecoder returns a TxDecoder that accepts both StdTx, amino
+// decoded as usual, and EthTx, accepted as a raw RLP-encoded Ethereum
+// transaction exactly as MetaMask and other Ethereum wallets produce it -
+// no amino framing required.
+func DefaultTxDecoder(cdc *codec.Codec) sdk.TxDecoder {
+	return func(txBytes []byte) (sdk.Tx, sdk.Error) {
+		if ethTx, ok := tryDecodeEthTx(txBytes); ok {
+			return ethTx, nil
+		}
+
+		var tx StdTx
+		if err := cdc.UnmarshalBinaryLengthPrefixed(txBytes, &tx); err != nil {
+			return nil, sdk.ErrTxDecode(err.Error())
+		}
+		return tx, nil
+	}
+}
+
+// tryDecodeEthTx RLP-decodes txBytes as an EthTx. A StdTx's amino framing
+// does not RLP-decode into EthTx's fixed, all-required-fields shape, so a
+// successful decode with a populated signature is a reliable signal that
+// txBytes is an EthTx rather than a misdecoded StdTx.
+func tryDecodeEthTx(txBytes []byte) (EthTx, bool) {
+	var ethTx EthTx
+	if err := rlp.DecodeBytes(txBytes, &ethTx); err != nil {
+		return EthTx{}, false
+	}
+	if ethTx.GasPrice == nil || ethTx.V == nil || ethTx.R == nil || ethTx.S == nil {
+		return EthTx{}, false
+	}
+	return ethTx, true
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	sdk "github.com/irisnet/irishub/types"
+)
+
+const ethRouterKey = "evm"
+
+var secp256k1Order, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+// secp256k1HalfOrder is half the secp256k1 curve order. A signature's S
+// value above this is the malleable mirror of a valid low-S signature
+// (S' = order - S verifies the same message) and is rejected by ValidateBasic.
+var secp256k1HalfOrder = new(big.Int).Rsh(secp256k1Order, 1)
+
+// EthTx is an Ethereum-formatted transaction: RLP-encoded and signed with a
+// secp256k1 signature over its own keccak256 hash, the way go-ethereum and
+// MetaMask produce it, rather than amino-encoded and signed like a StdTx.
+// It is accepted by NewEthAnteHandler alongside StdTx so EVM-style modules
+// and Ethereum wallets can submit to irishub directly.
+type EthTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	GasLimit uint64
+	To       []byte // 20-byte recipient address; empty for contract creation
+	Value    *big.Int
+	Data     []byte
+	V        *big.Int
+	R        *big.Int
+	S        *big.Int
+
+	// from caches the sender recovered by NewEthAnteHandler. It is unset
+	// until the signature has been verified, and GetSigners panics if
+	// called beforehand.
+	from sdk.AccAddress
+}
+
+// Route implements sdk.Msg.
+func (tx EthTx) Route() string { return ethRouterKey }
+
+// Type implements sdk.Msg.
+func (tx EthTx) Type() string { return "ethereum_tx" }
+
+// ValidateBasic implements sdk.Msg.
+func (tx EthTx) ValidateBasic() sdk.Error {
+	if tx.GasPrice == nil || tx.GasPrice.Sign() <= 0 {
+		return sdk.ErrInsufficientFee("eth tx gas price must be positive")
+	}
+	if tx.GasLimit == 0 {
+		return sdk.ErrInternal("eth tx gas limit must be positive")
+	}
+	if tx.Value != nil && tx.Value.Sign() < 0 {
+		return sdk.ErrInternal("eth tx value must be non-negative")
+	}
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return sdk.ErrUnauthorized("eth tx is not signed")
+	}
+	// R and S must fit the 32-byte field Sender packs them into; an RLP
+	// *big.Int carries no such bound on its own; rejecting oversized values
+	// here keeps Sender's copy into a fixed 65-byte buffer in range.
+	if len(tx.R.Bytes()) > 32 || len(tx.S.Bytes()) > 32 {
+		return sdk.ErrUnauthorized("eth tx signature R/S out of range")
+	}
+	// Reject the high-S form of a valid signature so a signature can't be
+	// malleated into a second, equally valid encoding of the same tx.
+	if tx.S.Cmp(secp256k1HalfOrder) > 0 {
+		return sdk.ErrUnauthorized("eth tx signature S is above the secp256k1 half order")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg. EthTx is never amino-signed; it carries
+// its own RLP-based signature instead.
+func (tx EthTx) GetSignBytes() []byte {
+	panic("EthTx is signed over its own RLP encoding, not amino sign bytes")
+}
+
+// GetSigners implements sdk.Msg, returning the sender recovered by
+// NewEthAnteHandler.
+func (tx EthTx) GetSigners() []sdk.AccAddress {
+	if tx.from.Empty() {
+		panic("EthTx sender accessed before signature verification")
+	}
+	return []sdk.AccAddress{tx.from}
+}
+
+// GetMsgs implements sdk.Tx. An EthTx carries exactly one message: itself.
+func (tx EthTx) GetMsgs() []sdk.Msg { return []sdk.Msg{tx} }
+
+// deriveChainIDAndRecoveryID splits an Ethereum signature's V value into the
+// EIP-155 chain ID mixed into it, if any, and the secp256k1 recovery ID.
+// A pre-EIP-155 V of 27 or 28 yields chain ID 0.
+func deriveChainIDAndRecoveryID(v *big.Int) (chainID *big.Int, recoveryID byte) {
+	if v.Cmp(big.NewInt(35)) < 0 {
+		return new(big.Int), byte(v.Uint64() - 27)
+	}
+	vMinus35 := new(big.Int).Sub(v, big.NewInt(35))
+	recoveryID = byte(new(big.Int).And(vMinus35, big.NewInt(1)).Uint64())
+	chainID = new(big.Int).Rsh(vMinus35, 1)
+	return chainID, recoveryID
+}
+
+// sigHash returns the keccak256 hash the signature was taken over: the
+// tx's RLP encoding, with the signed chainID (and two empty trailing
+// fields) mixed in per EIP-155, or without it for a legacy signature.
+func (tx EthTx) sigHash(chainID *big.Int) ([]byte, error) {
+	var fields []interface{}
+	if chainID.Sign() == 0 {
+		fields = []interface{}{tx.Nonce, tx.GasPrice, tx.GasLimit, tx.To, tx.Value, tx.Data}
+	} else {
+		fields = []interface{}{tx.Nonce, tx.GasPrice, tx.GasLimit, tx.To, tx.Value, tx.Data, chainID, uint(0), uint(0)}
+	}
+
+	bz, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(bz), nil
+}
+
+// Sender recovers the transaction's sender from its secp256k1 signature. If
+// the signature was taken over an EIP-155 chain ID, it must match chainID.
+func (tx EthTx) Sender(chainID *big.Int) (sdk.AccAddress, error) {
+	signedChainID, recoveryID := deriveChainIDAndRecoveryID(tx.V)
+	if signedChainID.Sign() != 0 && chainID.Sign() != 0 && signedChainID.Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("eth tx signed for chain %s, expected %s", signedChainID, chainID)
+	}
+
+	hash, err := tx.sigHash(signedChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[32-len(tx.R.Bytes()):32], tx.R.Bytes())
+	copy(sig[64-len(tx.S.Bytes()):64], tx.S.Bytes())
+	sig[64] = recoveryID
+
+	pubkey, err := crypto.Ecrecover(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	// An Ethereum address is the last 20 bytes of keccak256 over the
+	// uncompressed pubkey, sans its leading format byte.
+	return sdk.AccAddress(crypto.Keccak256(pubkey[1:])[12:]), nil
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func validEthTx(t *testing.T) (EthTx, *big.Int) {
+	t.Helper()
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	addr := crypto.PubkeyToAddress(priv.PublicKey)
+
+	chainID := big.NewInt(1)
+	tx := EthTx{
+		Nonce:    0,
+		GasPrice: big.NewInt(1),
+		GasLimit: 21000,
+		To:       addr.Bytes(),
+		Value:    big.NewInt(0),
+	}
+
+	hash, err := tx.sigHash(chainID)
+	if err != nil {
+		t.Fatalf("failed to hash tx: %v", err)
+	}
+	sig, err := crypto.Sign(hash, priv)
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	recoveryID := int64(sig[64])
+	// V = chainID*2 + 35 + recoveryID, per EIP-155.
+	tx.V = new(big.Int).Add(new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35)), big.NewInt(recoveryID))
+
+	return tx, chainID
+}
+
+// TestEthTxSenderRecoversSigner checks that Sender recovers the address
+// that actually produced the signature.
+func TestEthTxSenderRecoversSigner(t *testing.T) {
+	tx, chainID := validEthTx(t)
+
+	sender, err := tx.Sender(chainID)
+	if err != nil {
+		t.Fatalf("Sender returned an error for a validly signed tx: %v", err)
+	}
+	if sender.Empty() {
+		t.Fatalf("expected a non-empty recovered sender")
+	}
+	// validEthTx sends to the signing key's own address.
+	if !bytes.Equal(sender, tx.To) {
+		t.Fatalf("expected recovered sender %X to be the signer %X", []byte(sender), tx.To)
+	}
+}
+
+// TestEthTxSenderRejectsWrongChainID checks that a signature taken over one
+// EIP-155 chain ID is rejected when recovered against a different one.
+func TestEthTxSenderRejectsWrongChainID(t *testing.T) {
+	tx, _ := validEthTx(t)
+
+	if _, err := tx.Sender(big.NewInt(999)); err == nil {
+		t.Fatalf("expected Sender to reject a tx signed for a different chain ID")
+	}
+}
+
+// TestValidateBasicRejectsOversizedRS checks that ValidateBasic rejects an
+// R or S value wider than the 32-byte field Sender packs them into, rather
+// than letting Sender's fixed-size copy run out of range.
+func TestValidateBasicRejectsOversizedRS(t *testing.T) {
+	tx, _ := validEthTx(t)
+
+	oversized := new(big.Int).Lsh(big.NewInt(1), 257) // 33 bytes
+
+	rTx := tx
+	rTx.R = oversized
+	if err := rTx.ValidateBasic(); err == nil {
+		t.Fatalf("expected ValidateBasic to reject an oversized R")
+	}
+
+	sTx := tx
+	sTx.S = oversized
+	if err := sTx.ValidateBasic(); err == nil {
+		t.Fatalf("expected ValidateBasic to reject an oversized S")
+	}
+}
+
+// TestValidateBasicRejectsHighS checks that ValidateBasic rejects the
+// malleable high-S form of a signature.
+func TestValidateBasicRejectsHighS(t *testing.T) {
+	tx, _ := validEthTx(t)
+
+	// crypto.Sign always returns the canonical low-S signature; its
+	// malleable mirror, order-S, is the corresponding high-S value.
+	tx.S = new(big.Int).Sub(secp256k1Order, tx.S)
+	if tx.S.Cmp(secp256k1HalfOrder) <= 0 {
+		t.Fatalf("test setup error: expected the mirrored S to be high-S")
+	}
+
+	if err := tx.ValidateBasic(); err == nil {
+		t.Fatalf("expected ValidateBasic to reject a high-S signature")
+	}
+}
+
+// TestValidateBasicAcceptsLowS checks that a normally signed (low-S) tx
+// passes ValidateBasic's signature-shape checks.
+func TestValidateBasicAcceptsLowS(t *testing.T) {
+	tx, _ := validEthTx(t)
+
+	if err := tx.ValidateBasic(); err != nil {
+		t.Fatalf("expected a validly signed low-S tx to pass ValidateBasic, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// Fee generalizes the pricing model a StdTx pays with, so that today's flat
+// StdFee and tip/cap-based pricing schemes (e.g. DynamicFee) can be
+// validated and charged through the same ante-handler code paths.
+type Fee interface {
+	GetGas() uint64
+	GetAmount() sdk.Coins
+	GetPayer() sdk.AccAddress
+	Validate() sdk.Error
+}
+
+// GetGas implements Fee.
+func (fee StdFee) GetGas() uint64 { return fee.Gas }
+
+// GetAmount implements Fee.
+func (fee StdFee) GetAmount() sdk.Coins { return fee.Amount }
+
+// GetPayer implements Fee. It returns the Granter when fee-grant delegation
+// is used, and the empty address otherwise - callers fall back to the first
+// signer in that case.
+func (fee StdFee) GetPayer() sdk.AccAddress { return fee.Granter }
+
+// Validate implements Fee.
+func (fee StdFee) Validate() sdk.Error {
+	if fee.Gas <= 0 {
+		return sdk.ErrInternal(fmt.Sprintf("invalid gas supplied: %d", fee.Gas))
+	}
+	if !fee.Granter.Empty() && len(fee.Granter.Bytes()) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("invalid granter address: %s", fee.Granter))
+	}
+	return nil
+}
+
+// DynamicFee is an EIP-1559-style Fee: the payer names a tip (GasTipCap) and
+// an overall cap (GasFeeCap). ensureSufficientMempoolFees requires GasFeeCap
+// to cover the on-chain BaseFee, and DeductFeeDecorator charges GasFeeCap in
+// full (see GetAmount). Neither amount is scaled by Gas, and no
+// min(BaseFee+GasTipCap, GasFeeCap) effective price is computed or charged;
+// GasTipCap is only checked against GasFeeCap in Validate.
+type DynamicFee struct {
+	GasTipCap sdk.Coins `json:"gas_tip_cap"`
+	GasFeeCap sdk.Coins `json:"gas_fee_cap"`
+	Gas       uint64    `json:"gas"`
+}
+
+// GetGas implements Fee.
+func (fee DynamicFee) GetGas() uint64 { return fee.Gas }
+
+// GetAmount implements Fee. It reports GasFeeCap, which is the amount
+// DeductFeeDecorator charges; no smaller amount is settled or refunded later.
+func (fee DynamicFee) GetAmount() sdk.Coins { return fee.GasFeeCap }
+
+// GetPayer implements Fee. DynamicFee does not support fee-grant delegation.
+func (fee DynamicFee) GetPayer() sdk.AccAddress { return sdk.AccAddress{} }
+
+// Validate implements Fee.
+func (fee DynamicFee) Validate() sdk.Error {
+	if fee.Gas <= 0 {
+		return sdk.ErrInternal(fmt.Sprintf("invalid gas supplied: %d", fee.Gas))
+	}
+	if !fee.GasFeeCap.IsAllGTE(fee.GasTipCap) {
+		return sdk.ErrInsufficientFee("gas fee cap must be greater than or equal to the gas tip cap")
+	}
+	return nil
+}
+
+// requiredFee returns the minimum fee a tx must pay: the on-chain BaseFee
+// when bfk is set, falling back to the validator's local minimum fees
+// otherwise.
+func requiredFee(ctx sdk.Context, bfk BaseFeeKeeper) sdk.Coins {
+	if bfk != nil {
+		if baseFee := bfk.GetBaseFee(ctx); !baseFee.Empty() {
+			return baseFee
+		}
+	}
+	return ctx.MinimumFees()
+}
+
+func ensureSufficientMempoolFees(ctx sdk.Context, fee Fee, bfk BaseFeeKeeper) sdk.Result {
+	// The required fee is a flat amount - the on-chain BaseFee, or the
+	// validator's local minimum fees - that is not scaled by the tx's gas.
+	//
+	// TODO:
+	// - Account for tx size.
+	// - Make Gas an unsigned integer and use tx basic validation
+	if err := fee.Validate(); err != nil {
+		return err.Result()
+	}
+
+	switch fee := fee.(type) {
+	case DynamicFee:
+		return ensureSufficientDynamicFee(ctx, fee, bfk)
+	default:
+		required := requiredFee(ctx, bfk)
+		// NOTE: !A.IsAllGTE(B) is not the same as A.IsAllLT(B).
+		if !required.IsZero() && !fee.GetAmount().IsAllGTE(required) {
+			// validators reject any tx from the mempool whose total fee is below the required amount itself;
+			// the required amount is not multiplied by the tx's gas
+			return sdk.ErrInsufficientFee(fmt.Sprintf(
+				"insufficient fee, got: %q required: %q", fee.GetAmount(), required)).Result()
+		}
+		return sdk.Result{}
+	}
+}
+
+// ensureSufficientDynamicFee checks that a DynamicFee's GasFeeCap covers the
+// required BaseFee. The amount DeductFeeDecorator charges is GasFeeCap itself
+// (see DynamicFee.GetAmount), not min(BaseFee+tip, cap).
+func ensureSufficientDynamicFee(ctx sdk.Context, fee DynamicFee, bfk BaseFeeKeeper) sdk.Result {
+	required := requiredFee(ctx, bfk)
+	if !required.IsZero() && !fee.GasFeeCap.IsAllGTE(required) {
+		return sdk.ErrInsufficientFee(fmt.Sprintf(
+			"insufficient gas fee cap, got: %q required at least: %q", fee.GasFeeCap, required)).Result()
+	}
+	return sdk.Result{}
+}
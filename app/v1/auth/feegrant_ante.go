@@ -0,0 +1,13 @@
+package auth
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// FeeGrantKeeper is consulted by DeductFeeDecorator whenever a StdTx names a
+// fee Granter distinct from its first signer. It is implemented by the
+// x/feegrant keeper; apps that don't wire up fee grants can pass nil, in
+// which case a tx with a Granter set is rejected.
+type FeeGrantKeeper interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error
+}
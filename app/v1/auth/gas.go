@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+)
+
+const (
+	ed25519VerifyCost   = 59
+	secp256k1VerifyCost = 100
+)
+
+const (
+	ethTxIntrinsicGas   uint64 = 21000 // flat cost of an eth tx, before calldata
+	ethTxDataZeroGas    uint64 = 4     // per zero byte of calldata
+	ethTxDataNonzeroGas uint64 = 68    // per non-zero byte of calldata
+)
+
+// intrinsicGas computes the flat, pre-execution gas cost of an EthTx's
+// calldata, mirroring go-ethereum's IntrinsicGas for non-contract-creation
+// transactions.
+func intrinsicGas(data []byte) uint64 {
+	gas := ethTxIntrinsicGas
+	for _, b := range data {
+		if b == 0 {
+			gas += ethTxDataZeroGas
+		} else {
+			gas += ethTxDataNonzeroGas
+		}
+	}
+	return gas
+}
+
+// SignatureVerificationGasConsumer prices verifying a single signature
+// against a pubkey, charging the result to meter. Apps that register
+// additional key schemes (e.g. sr25519, BLS, Ethereum-style secp256k1) pass
+// their own consumer into NewAnteHandler instead of patching the auth
+// module's dispatch.
+type SignatureVerificationGasConsumer func(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey) sdk.Result
+
+// DefaultSigVerificationGasConsumer is the standard
+// SignatureVerificationGasConsumer; apps pass it to NewAnteHandler
+// explicitly, which does not substitute it for a nil consumer. It
+// prices ed25519, secp256k1, and multisig-threshold pubkeys.
+func DefaultSigVerificationGasConsumer(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey) sdk.Result {
+	pubkeyType := strings.ToLower(fmt.Sprintf("%T", pubkey))
+
+	switch {
+	case strings.Contains(pubkeyType, "ed25519"):
+		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
+		return sdk.Result{}
+
+	case strings.Contains(pubkeyType, "secp256k1"):
+		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
+		return sdk.Result{}
+
+	case strings.Contains(pubkeyType, "multisigthreshold"):
+		var multisignature multisig.Multisignature
+		codec.Cdc.MustUnmarshalBinaryBare(sig, &multisignature)
+
+		multisigPubKey := pubkey.(multisig.PubKeyMultisigThreshold)
+		consumeMultisignatureVerificationGas(meter, multisignature, multisigPubKey, DefaultSigVerificationGasConsumer)
+		return sdk.Result{}
+
+	default:
+		return sdk.ErrInvalidPubKey(fmt.Sprintf("unrecognized public key type: %s", pubkeyType)).Result()
+	}
+}
+
+func consumeMultisignatureVerificationGas(meter sdk.GasMeter,
+	sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold, consumer SignatureVerificationGasConsumer) {
+
+	size := sig.BitArray.Size()
+	sigIndex := 0
+	for i := 0; i < size; i++ {
+		if sig.BitArray.GetIndex(i) {
+			consumer(meter, sig.Sigs[sigIndex], pubkey.PubKeys[i])
+			sigIndex++
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// StdFee is the fee a StdTx pays: Gas units wanted and the Coins paid for
+// them. Granter is optional; when set, DeductFeeDecorator charges the fee
+// to the named account via FeeGrantKeeper instead of the tx's first
+// signer - see NewDeductFeeDecorator and GetPayer. Because StdSignBytes
+// signs over the whole Fee, a signature is invalidated if Granter is
+// changed after the fact, the same as any other Fee field.
+type StdFee struct {
+	Amount  sdk.Coins      `json:"amount"`
+	Gas     uint64         `json:"gas"`
+	Granter sdk.AccAddress `json:"granter,omitempty"`
+}
+
+// StdSignature is a signer's signature over a StdTx's sign bytes, together
+// with the account number and sequence it was signed at.
+type StdSignature struct {
+	PubKey        crypto.PubKey `json:"pub_key"`
+	Signature     []byte        `json:"signature"`
+	AccountNumber uint64        `json:"account_number"`
+	Sequence      uint64        `json:"sequence"`
+}
+
+// StdTx is the standard, amino-signed transaction: one or more Msgs, the
+// Fee paid to include them, a Signature per signer in the same order as
+// GetSigners, and an optional Memo. Fee is the Fee interface rather than
+// the concrete StdFee so a StdTx can carry either a flat StdFee or a
+// DynamicFee - see RegisterCodec, which registers both as Fee implementations.
+type StdTx struct {
+	Msgs       []sdk.Msg      `json:"msg"`
+	Fee        Fee            `json:"fee"`
+	Signatures []StdSignature `json:"signatures"`
+	Memo       string         `json:"memo"`
+}
+
+// GetMsgs implements sdk.Tx.
+func (tx StdTx) GetMsgs() []sdk.Msg { return tx.Msgs }
+
+// ValidateBasic implements sdk.Tx, running the Fee's own checks and each
+// Msg's ValidateBasic.
+func (tx StdTx) ValidateBasic() sdk.Error {
+	if err := tx.Fee.Validate(); err != nil {
+		return err
+	}
+	for _, msg := range tx.Msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSigners returns the address of every signer, collected from each
+// Msg's own GetSigners and deduplicated in first-seen order - the order
+// GetSignatures must match.
+func (tx StdTx) GetSigners() []sdk.AccAddress {
+	seen := make(map[string]bool)
+	var signers []sdk.AccAddress
+	for _, msg := range tx.Msgs {
+		for _, addr := range msg.GetSigners() {
+			key := addr.String()
+			if !seen[key] {
+				seen[key] = true
+				signers = append(signers, addr)
+			}
+		}
+	}
+	return signers
+}
+
+// GetSignatures returns the tx's signatures, in the same order as GetSigners.
+func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
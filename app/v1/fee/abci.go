@@ -0,0 +1,43 @@
+package fee
+
+import (
+	"github.com/irisnet/irishub/app/v1/fee/internal/keeper"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// EndBlocker adjusts BaseFee based on the sliding window of recent block
+// gas utilization: when the average utilization is above TargetUtil,
+// BaseFee is raised; when below, it is lowered. The per-block change is
+// capped by MaxChange and the result is clamped to [MinBaseFee, MaxBaseFee].
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	params := k.GetParams(ctx)
+
+	if gasLimit := ctx.ConsensusParams().Block.MaxGas; gasLimit > 0 {
+		gasUsed := int64(ctx.BlockGasMeter().GasConsumedToLimit())
+		k.RecordBlock(ctx, gasUsed, gasLimit)
+	}
+
+	avgUtil := k.AverageUtilization(ctx)
+	// change is proportional to how far utilization is from its target,
+	// capped at +/-MaxChange per block (mirrors EIP-1559's 12.5% cap).
+	change := avgUtil.Sub(params.TargetUtil)
+	if change.GT(params.MaxChange) {
+		change = params.MaxChange
+	}
+	if change.LT(params.MaxChange.Neg()) {
+		change = params.MaxChange.Neg()
+	}
+
+	baseFee := k.GetBaseFee(ctx)
+	adjusted := sdk.NewDecCoins(baseFee).MulDec(sdk.OneDec().Add(change))
+	newBaseFee := adjusted.TruncateDecimal()
+
+	if !params.MinBaseFee.Empty() && newBaseFee.IsAllLT(params.MinBaseFee) {
+		newBaseFee = params.MinBaseFee
+	}
+	if !params.MaxBaseFee.Empty() && newBaseFee.IsAllGT(params.MaxBaseFee) {
+		newBaseFee = params.MaxBaseFee
+	}
+
+	k.SetBaseFee(ctx, newBaseFee)
+}
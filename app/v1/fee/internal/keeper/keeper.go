@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"github.com/irisnet/irishub/app/v1/fee/internal/types"
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// Keeper maintains the on-chain BaseFee and the sliding window of recent
+// block gas utilization used to adjust it.
+type Keeper struct {
+	key sdk.StoreKey
+	cdc *codec.Codec
+}
+
+// NewKeeper returns a new fee Keeper.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{key: key, cdc: cdc}
+}
+
+// GetParams returns the module's current parameters, or the defaults if
+// none have been set yet; this module does not set them at genesis.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(paramsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &params)
+	return params
+}
+
+// SetParams sets the module's parameters. This module keeps its own copy
+// of Params in its store rather than registering a params.Subspace - no
+// params subspace module exists in this tree - so SetParams is not yet
+// reachable from a gov param-change proposal; that wiring is left for
+// when that infra lands, and until then GetParams' DefaultParams fallback
+// is what's actually in effect on a freshly initialized chain.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	store := ctx.KVStore(k.key)
+	store.Set(paramsKey, k.cdc.MustMarshalBinaryLengthPrefixed(params))
+}
+
+// GetBaseFee returns the current BaseFee. It implements auth.BaseFeeKeeper.
+func (k Keeper) GetBaseFee(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(baseFeeKey)
+	if bz == nil {
+		return k.GetParams(ctx).MinBaseFee
+	}
+
+	var baseFee sdk.Coins
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &baseFee)
+	return baseFee
+}
+
+// SetBaseFee sets the current BaseFee.
+func (k Keeper) SetBaseFee(ctx sdk.Context, baseFee sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	store.Set(baseFeeKey, k.cdc.MustMarshalBinaryLengthPrefixed(baseFee))
+}
+
+// RecordBlock stores the current block's gas utilization sample for the
+// sliding window. Called once per block, from EndBlocker, before it reads
+// AverageUtilization.
+func (k Keeper) RecordBlock(ctx sdk.Context, gasUsed, gasLimit int64) {
+	store := ctx.KVStore(k.key)
+	entry := types.BlockUtilization{
+		Height:   ctx.BlockHeight(),
+		GasUsed:  gasUsed,
+		GasLimit: gasLimit,
+	}
+	store.Set(GetWindowEntryKey(entry.Height), k.cdc.MustMarshalBinaryLengthPrefixed(entry))
+
+	window := k.GetParams(ctx).Window
+	oldest := entry.Height - window
+	if oldest > 0 {
+		store.Delete(GetWindowEntryKey(oldest))
+	}
+}
+
+// AverageUtilization returns the average gas utilization (gasUsed/gasLimit)
+// across whatever samples remain in the sliding window.
+func (k Keeper) AverageUtilization(ctx sdk.Context) sdk.Dec {
+	window := k.GetParams(ctx).Window
+	height := ctx.BlockHeight()
+
+	store := ctx.KVStore(k.key)
+	sum := sdk.ZeroDec()
+	count := int64(0)
+	for h := height - window + 1; h <= height; h++ {
+		bz := store.Get(GetWindowEntryKey(h))
+		if bz == nil {
+			continue
+		}
+
+		var entry types.BlockUtilization
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &entry)
+		if entry.GasLimit == 0 {
+			continue
+		}
+
+		sum = sum.Add(sdk.NewDec(entry.GasUsed).QuoInt64(entry.GasLimit))
+		count++
+	}
+
+	if count == 0 {
+		return k.GetParams(ctx).TargetUtil
+	}
+	return sum.QuoInt64(count)
+}
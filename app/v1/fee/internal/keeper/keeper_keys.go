@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	"encoding/binary"
+)
+
+var (
+	// Keys for store prefixes
+	paramsKey      = []byte{0x01}
+	baseFeeKey     = []byte{0x02}
+	windowEntryKey = []byte{0x03}
+)
+
+// GetWindowEntryKey returns the key for the recorded utilization of the
+// block at the given height.
+func GetWindowEntryKey(height int64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = windowEntryKey[0]
+	binary.BigEndian.PutUint64(key[1:9], uint64(height))
+	return key
+}
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// newTestKeeper returns a Keeper backed by a fresh in-memory store, for
+// exercising RecordBlock/AverageUtilization without a running app.
+func newTestKeeper(t *testing.T) (Keeper, sdk.Context) {
+	key := sdk.NewKVStoreKey("fee")
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	if err := cms.LoadLatestVersion(); err != nil {
+		t.Fatalf("failed to load store: %v", err)
+	}
+
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+	return NewKeeper(codec.New(), key), ctx
+}
+
+// TestAverageUtilizationEmptyWindow checks that an untouched window falls
+// back to the default TargetUtil, rather than reading as 0% utilized.
+func TestAverageUtilizationEmptyWindow(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+
+	got := k.AverageUtilization(ctx)
+	want := k.GetParams(ctx).TargetUtil
+	if !got.Equal(want) {
+		t.Fatalf("expected empty window to report the default TargetUtil %s, got %s", want, got)
+	}
+}
+
+// TestAverageUtilizationAveragesRecordedBlocks checks that AverageUtilization
+// reports the mean of gasUsed/gasLimit across every block RecordBlock has
+// stored within the current window.
+func TestAverageUtilizationAveragesRecordedBlocks(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+
+	// Block 1: 50% utilized, block 2: 100% utilized -> average 75%.
+	ctx1 := ctx.WithBlockHeight(1)
+	k.RecordBlock(ctx1, 50, 100)
+	ctx2 := ctx.WithBlockHeight(2)
+	k.RecordBlock(ctx2, 100, 100)
+
+	got := k.AverageUtilization(ctx2)
+	want := sdk.NewDecWithPrec(75, 2)
+	if !got.Equal(want) {
+		t.Fatalf("expected average utilization %s, got %s", want, got)
+	}
+}
+
+// TestRecordBlockEvictsOutsideWindow checks that RecordBlock prunes samples
+// that have fallen out of the trailing Window, so AverageUtilization doesn't
+// keep averaging in arbitrarily old blocks.
+func TestRecordBlockEvictsOutsideWindow(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	window := k.GetParams(ctx).Window
+
+	// The block at height 1 is fully utilized; once the window has slid
+	// past it, only the later, empty blocks should count. RecordBlock at
+	// height 1+window is the call that prunes height 1.
+	k.RecordBlock(ctx.WithBlockHeight(1), 100, 100)
+
+	evictHeight := 1 + window
+	k.RecordBlock(ctx.WithBlockHeight(evictHeight), 0, 100)
+
+	if ctx.KVStore(k.key).Has(GetWindowEntryKey(1)) {
+		t.Fatalf("expected RecordBlock at height %d to prune the sample at height 1", evictHeight)
+	}
+
+	got := k.AverageUtilization(ctx.WithBlockHeight(evictHeight))
+	want := sdk.ZeroDec()
+	if !got.Equal(want) {
+		t.Fatalf("expected the evicted block at height 1 to be excluded, got average %s", got)
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+const (
+	// ModuleName is the name of the dynamic base-fee module.
+	ModuleName = "fee"
+
+	// QuerierRoute is the querier route for the fee module.
+	QuerierRoute = ModuleName
+
+	// QueryBaseFee is the path for querying the current BaseFee, exposed at
+	// /fee/base_fee.
+	QueryBaseFee = "base_fee"
+)
+
+// Params governs how BaseFee is adjusted at the end of every block, based
+// on a sliding window of recent block gas utilization. Stored and updated
+// via Keeper.SetParams; see its doc comment for the current lack of a
+// params.Subspace to drive that from governance.
+type Params struct {
+	// Window is the number of trailing blocks averaged when computing utilization.
+	Window int64 `json:"window"`
+
+	// TargetUtil is the target average utilization in (0,1]; BaseFee rises
+	// when the observed average is above it, and falls when below.
+	TargetUtil sdk.Dec `json:"target_util"`
+
+	// MaxChange caps the fractional change applied to BaseFee in a single
+	// block, e.g. 0.125 permits at most a 12.5% move per block.
+	MaxChange sdk.Dec `json:"max_change"`
+
+	// MinBaseFee and MaxBaseFee clamp the adjusted BaseFee.
+	MinBaseFee sdk.Coins `json:"min_base_fee"`
+	MaxBaseFee sdk.Coins `json:"max_base_fee"`
+}
+
+// DefaultParams returns the module's default parameters.
+func DefaultParams() Params {
+	return Params{
+		Window:     100,
+		TargetUtil: sdk.NewDecWithPrec(5, 1),
+		MaxChange:  sdk.NewDecWithPrec(125, 3),
+	}
+}
+
+// BlockUtilization is a single sample in the sliding window used by the
+// BaseFee adjustment rule: how much gas a block used relative to its limit.
+type BlockUtilization struct {
+	Height   int64 `json:"height"`
+	GasUsed  int64 `json:"gas_used"`
+	GasLimit int64 `json:"gas_limit"`
+}
@@ -0,0 +1,35 @@
+package fee
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/irisnet/irishub/app/v1/fee/internal/keeper"
+	"github.com/irisnet/irishub/app/v1/fee/internal/types"
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// NewQuerier returns the querier for the fee module, serving
+// /custom/fee/base_fee.
+func NewQuerier(k keeper.Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryBaseFee:
+			return queryBaseFee(ctx, k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown fee query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryBaseFee(ctx sdk.Context, k keeper.Keeper) ([]byte, sdk.Error) {
+	baseFee := k.GetBaseFee(ctx)
+
+	bz, err := codec.Cdc.MarshalJSONIndent(baseFee, "", "  ")
+	if err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal base fee: %s", err))
+	}
+	return bz, nil
+}
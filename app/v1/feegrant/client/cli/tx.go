@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/types"
+	"github.com/irisnet/irishub/client/context"
+	"github.com/irisnet/irishub/client/utils"
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+const (
+	flagPeriod           = "period"
+	flagPeriodSpendLimit = "period-spend-limit"
+)
+
+// GetCmdGrantFeeAllowance implements the grant-fee-allowance command, which
+// grants a fee allowance from the sending account to a grantee address: a
+// BasicAllowance by default, or a PeriodicAllowance when --period is set.
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant-fee-allowance [grantee] [spend-limit] [expiration]",
+		Short: "Grant a fee allowance from your account to a grantee",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txCtx := utils.NewTxContextFromCLI().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			spendLimit, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			var expiration int64
+			if _, err := fmt.Sscanf(args[2], "%d", &expiration); err != nil {
+				return err
+			}
+
+			basic := types.BasicAllowance{SpendLimit: spendLimit, Expiration: expiration}
+
+			var allowance types.Allowance = &basic
+			if period := viper.GetInt64(flagPeriod); period > 0 {
+				periodSpendLimit, err := sdk.ParseCoins(viper.GetString(flagPeriodSpendLimit))
+				if err != nil {
+					return err
+				}
+				allowance = &types.PeriodicAllowance{
+					BasicAllowance:   basic,
+					Period:           period,
+					PeriodSpendLimit: periodSpendLimit,
+					PeriodCanSpend:   periodSpendLimit,
+				}
+			}
+
+			msg := types.NewMsgGrantFeeAllowance(cliCtx.GetFromAddress(), grantee, allowance)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(flagPeriod, 0, "grant a PeriodicAllowance instead of a BasicAllowance, resetting every this many blocks")
+	cmd.Flags().String(flagPeriodSpendLimit, "", "max coins spendable per period (required with --period)")
+	return cmd
+}
+
+// GetCmdRevokeFeeAllowance implements the revoke-fee-allowance command.
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-fee-allowance [grantee]",
+		Short: "Revoke a fee allowance previously granted to a grantee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txCtx := utils.NewTxContextFromCLI().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeFeeAllowance(cliCtx.GetFromAddress(), grantee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,36 @@
+package feegrant
+
+import (
+	"fmt"
+
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/keeper"
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/types"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// NewHandler returns a handler for fee-grant messages.
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case types.MsgGrantFeeAllowance:
+			return handleMsgGrantFeeAllowance(ctx, k, msg)
+		case types.MsgRevokeFeeAllowance:
+			return handleMsgRevokeFeeAllowance(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("unrecognized feegrant message type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k keeper.Keeper, msg types.MsgGrantFeeAllowance) sdk.Result {
+	k.GrantFeeAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance)
+	return sdk.Result{}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k keeper.Keeper, msg types.MsgRevokeFeeAllowance) sdk.Result {
+	if err := k.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/types"
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// Keeper manages fee allowances: who has granted a fee allowance to whom,
+// and how much of it has been spent.
+type Keeper struct {
+	key sdk.StoreKey
+	cdc *codec.Codec
+}
+
+// NewKeeper returns a new fee-grant Keeper.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{key: key, cdc: cdc}
+}
+
+// GrantFeeAllowance stores an allowance granted by granter to grantee,
+// replacing any existing grant between the pair.
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress, allowance types.Allowance) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(&allowance)
+	store.Set(GetFeeAllowanceKey(granter, grantee), bz)
+}
+
+// RevokeFeeAllowance removes any allowance granted by granter to grantee.
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) sdk.Error {
+	store := ctx.KVStore(k.key)
+	key := GetFeeAllowanceKey(granter, grantee)
+	if !store.Has(key) {
+		return sdk.ErrUnknownRequest("fee allowance not found")
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetFeeAllowance returns the allowance granted by granter to grantee, or
+// nil if none exists.
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) types.Allowance {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return nil
+	}
+
+	var allowance types.Allowance
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &allowance)
+	return allowance
+}
+
+// UseGrantedFees charges fee against the allowance granted by granter to
+// grantee, persisting the updated allowance, and removing it once it is
+// exhausted. It implements auth.FeeGrantKeeper.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	allowance := k.GetFeeAllowance(ctx, granter, grantee)
+	if allowance == nil {
+		return sdk.ErrUnknownRequest("no fee allowance granted")
+	}
+
+	remove, err := allowance.Accept(ctx, fee, ctx.BlockHeight())
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		store := ctx.KVStore(k.key)
+		store.Delete(GetFeeAllowanceKey(granter, grantee))
+		return nil
+	}
+
+	k.GrantFeeAllowance(ctx, granter, grantee, allowance)
+	return nil
+}
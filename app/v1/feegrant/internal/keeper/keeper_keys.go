@@ -0,0 +1,22 @@
+package keeper
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+var (
+	// Keys for store prefixes
+	feeAllowanceKey = []byte{0x01}
+)
+
+// GetFeeAllowanceKey returns the key under which the allowance granted by
+// granter to grantee is stored.
+func GetFeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(append(feeAllowanceKey, granter.Bytes()...), grantee.Bytes()...)
+}
+
+// GetFeeAllowancePrefix returns the key prefix for all allowances granted by
+// granter, regardless of grantee.
+func GetFeeAllowancePrefix(granter sdk.AccAddress) []byte {
+	return append(feeAllowanceKey, granter.Bytes()...)
+}
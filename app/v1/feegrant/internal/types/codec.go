@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/irisnet/irishub/codec"
+)
+
+// msgCdc is used for MsgGrantFeeAllowance/MsgRevokeFeeAllowance sign-bytes
+// only; the module codec is registered into the app-wide codec via
+// RegisterCodec.
+var msgCdc = codec.New()
+
+// RegisterCodec registers the fee-grant module's concrete types.
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*Allowance)(nil), nil)
+	cdc.RegisterConcrete(&BasicAllowance{}, "irishub/feegrant/BasicAllowance", nil)
+	cdc.RegisterConcrete(&PeriodicAllowance{}, "irishub/feegrant/PeriodicAllowance", nil)
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "irishub/feegrant/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "irishub/feegrant/MsgRevokeFeeAllowance", nil)
+}
+
+func init() {
+	RegisterCodec(msgCdc)
+	msgCdc.Seal()
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// MsgGrantFeeAllowance grants an Allowance from Granter to Grantee, replacing
+// any existing grant between the same pair.
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance Allowance      `json:"allowance"`
+}
+
+// NewMsgGrantFeeAllowance returns a new MsgGrantFeeAllowance.
+func NewMsgGrantFeeAllowance(granter, grantee sdk.AccAddress, allowance Allowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{Granter: granter, Grantee: grantee, Allowance: allowance}
+}
+
+// Route implements sdk.Msg.
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+
+// Type implements sdk.Msg.
+func (msg MsgGrantFeeAllowance) Type() string { return "grant_fee_allowance" }
+
+// ValidateBasic implements sdk.Msg.
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	if msg.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	if msg.Granter.Equals(msg.Grantee) {
+		return sdk.ErrInvalidAddress("grantee cannot be the same as granter")
+	}
+	if msg.Allowance == nil {
+		return sdk.ErrUnknownRequest("missing allowance")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg.
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg.
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeFeeAllowance revokes any Allowance from Granter to Grantee.
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+// NewMsgRevokeFeeAllowance returns a new MsgRevokeFeeAllowance.
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{Granter: granter, Grantee: grantee}
+}
+
+// Route implements sdk.Msg.
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+
+// Type implements sdk.Msg.
+func (msg MsgRevokeFeeAllowance) Type() string { return "revoke_fee_allowance" }
+
+// ValidateBasic implements sdk.Msg.
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	if msg.Granter.Empty() {
+		return sdk.ErrInvalidAddress("missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdk.ErrInvalidAddress("missing grantee address")
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg.
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg.
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	sdk "github.com/irisnet/irishub/types"
+)
+
+const (
+	// ModuleName is the name of the fee-grant module.
+	ModuleName = "feegrant"
+
+	// RouterKey is the message route for the fee-grant module.
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the fee-grant module.
+	QuerierRoute = ModuleName
+
+	// QueryFeeAllowance is the path for querying the allowance granted by
+	// one account to another, exposed at /feegrant/allowance/{granter}/{grantee}.
+	QueryFeeAllowance = "allowance"
+)
+
+// Allowance defines the behavior of a fee allowance granted by a granter to
+// a grantee: how much of a requested fee it covers, and whether it still has
+// any balance left afterwards.
+type Allowance interface {
+	// Accept is called by the keeper when the grantee submits a tx whose fee
+	// is to be paid by the granter. It mutates the allowance's remaining
+	// balance in place (the keeper persists it back unless remove is true,
+	// in which case the grant is deleted) and reports whether the fee is
+	// covered at all.
+	Accept(ctx sdk.Context, fee sdk.Coins, blockHeight int64) (remove bool, err sdk.Error)
+}
+
+// BasicAllowance covers any number of fee payments, up to SpendLimit in
+// total, until it is exhausted or the block height passes Expiration (0
+// means no expiration); a payment at height Expiration itself is accepted.
+type BasicAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit"`
+	Expiration int64     `json:"expiration"`
+}
+
+// Accept implements Allowance.
+func (a *BasicAllowance) Accept(ctx sdk.Context, fee sdk.Coins, blockHeight int64) (bool, sdk.Error) {
+	if a.Expiration != 0 && blockHeight > a.Expiration {
+		return true, sdk.ErrUnknownRequest("fee allowance expired")
+	}
+
+	remaining, hasNeg := a.SpendLimit.SafeSub(fee)
+	if hasNeg {
+		return false, sdk.ErrInsufficientFunds("fee allowance exceeded")
+	}
+	a.SpendLimit = remaining
+
+	return remaining.IsZero(), nil
+}
+
+// PeriodicAllowance covers fee payments up to PeriodSpendLimit within each
+// Period (measured in blocks), refilling back to PeriodSpendLimit at the
+// start of every period, in addition to the BasicAllowance's overall cap.
+type PeriodicAllowance struct {
+	BasicAllowance
+
+	// Period is the number of blocks over which PeriodSpendLimit applies.
+	Period int64 `json:"period"`
+
+	// PeriodSpendLimit is the maximum that can be spent within one period.
+	PeriodSpendLimit sdk.Coins `json:"period_spend_limit"`
+
+	// PeriodCanSpend is what remains of PeriodSpendLimit in the current period.
+	PeriodCanSpend sdk.Coins `json:"period_can_spend"`
+
+	// PeriodReset is the block height at which the current period, and thus
+	// PeriodCanSpend, resets.
+	PeriodReset int64 `json:"period_reset"`
+}
+
+// Accept implements Allowance.
+func (a *PeriodicAllowance) Accept(ctx sdk.Context, fee sdk.Coins, blockHeight int64) (bool, sdk.Error) {
+	if blockHeight >= a.PeriodReset {
+		a.PeriodCanSpend = a.PeriodSpendLimit
+		a.PeriodReset = blockHeight + a.Period
+	}
+
+	periodRemaining, hasNeg := a.PeriodCanSpend.SafeSub(fee)
+	if hasNeg {
+		return false, sdk.ErrInsufficientFunds("fee allowance period limit exceeded")
+	}
+	a.PeriodCanSpend = periodRemaining
+
+	return a.BasicAllowance.Accept(ctx, fee, blockHeight)
+}
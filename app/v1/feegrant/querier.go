@@ -0,0 +1,51 @@
+package feegrant
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/keeper"
+	"github.com/irisnet/irishub/app/v1/feegrant/internal/types"
+	"github.com/irisnet/irishub/codec"
+	sdk "github.com/irisnet/irishub/types"
+)
+
+// NewQuerier returns the querier for the fee-grant module, serving
+// /custom/feegrant/allowance/{granter}/{grantee}.
+func NewQuerier(k keeper.Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryFeeAllowance:
+			return queryFeeAllowance(ctx, path[1:], k)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown fee-grant query endpoint: %s", path[0]))
+		}
+	}
+}
+
+func queryFeeAllowance(ctx sdk.Context, args []string, k keeper.Keeper) ([]byte, sdk.Error) {
+	if len(args) != 2 {
+		return nil, sdk.ErrUnknownRequest("expected query path custom/feegrant/allowance/{granter}/{grantee}")
+	}
+
+	granter, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid granter address: %s", args[0]))
+	}
+	grantee, err := sdk.AccAddressFromBech32(args[1])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid grantee address: %s", args[1]))
+	}
+
+	allowance := k.GetFeeAllowance(ctx, granter, grantee)
+	if allowance == nil {
+		return nil, sdk.ErrUnknownRequest("no fee allowance granted")
+	}
+
+	bz, cdcErr := codec.Cdc.MarshalJSONIndent(allowance, "", "  ")
+	if cdcErr != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal fee allowance: %s", cdcErr))
+	}
+	return bz, nil
+}